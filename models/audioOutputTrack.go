@@ -0,0 +1,144 @@
+package models
+
+import "fmt"
+
+// AudioOutputTrack defines a single encoded audio rendition of a stream
+// output variant. A variant can carry more than one of these to offer
+// multiple languages, or a low-bitrate rendition alongside a higher quality
+// one.
+type AudioOutputTrack struct {
+	// Codec is the audio codec used to encode this track: aac, opus, or mp3.
+	Codec string `yaml:"codec" json:"codec"`
+	// Bitrate is the audio bitrate in kbps.
+	Bitrate int `yaml:"bitrate" json:"bitrate"`
+	// Channels is the number of audio channels. Defaults to 2 (stereo).
+	Channels int `yaml:"channels" json:"channels,omitempty"`
+	// SampleRate is the output sample rate in Hz. Defaults to 44100.
+	SampleRate int `yaml:"sampleRate" json:"sampleRate,omitempty"`
+	// Language is the BCP 47 language tag for this track, used to populate
+	// the HLS `LANGUAGE` attribute. Optional.
+	Language string `yaml:"language" json:"language,omitempty"`
+	// Name is the human-readable name for this track shown to players that
+	// expose rendition selection. Defaults to the codec name.
+	Name string `yaml:"name" json:"name,omitempty"`
+	// Default marks this as the track a player should select automatically.
+	// Exactly one track per variant should be marked default.
+	Default bool `yaml:"default" json:"default,omitempty"`
+}
+
+// audioEncoderNames maps each supported audio codec to its ffmpeg encoder.
+var audioEncoderNames = map[string]string{
+	"aac":  "aac",
+	"opus": "libopus",
+	"mp3":  "libmp3lame",
+}
+
+// GetCodec returns the configured audio codec, defaulting to aac.
+func (t *AudioOutputTrack) GetCodec() string {
+	if t.Codec == "" {
+		return "aac"
+	}
+
+	return t.Codec
+}
+
+// GetBitrate returns the configured bitrate or a sane default.
+func (t *AudioOutputTrack) GetBitrate() int {
+	if t.Bitrate > 0 {
+		return t.Bitrate
+	}
+
+	return 79
+}
+
+// GetChannels returns the configured channel count, defaulting to stereo.
+func (t *AudioOutputTrack) GetChannels() int {
+	if t.Channels > 0 {
+		return t.Channels
+	}
+
+	return 2
+}
+
+// GetSampleRate returns the configured sample rate, defaulting to 44100Hz.
+func (t *AudioOutputTrack) GetSampleRate() int {
+	if t.SampleRate > 0 {
+		return t.SampleRate
+	}
+
+	return 44100
+}
+
+// GetName returns the configured display name, defaulting to the codec name.
+func (t *AudioOutputTrack) GetName() string {
+	if t.Name != "" {
+		return t.Name
+	}
+
+	return t.GetCodec()
+}
+
+// GetEncoderArgs returns the ffmpeg arguments needed to encode this track.
+func (t *AudioOutputTrack) GetEncoderArgs() []string {
+	encoder, ok := audioEncoderNames[t.GetCodec()]
+	if !ok {
+		encoder = audioEncoderNames["aac"]
+	}
+
+	return []string{
+		"-c:a", encoder,
+		"-b:a", fmt.Sprintf("%dk", t.GetBitrate()),
+		"-ac", fmt.Sprintf("%d", t.GetChannels()),
+		"-ar", fmt.Sprintf("%d", t.GetSampleRate()),
+	}
+}
+
+// ValidateAudioTrack returns an error if this track requests a codec we
+// don't have an encoder for. A misspelled Codec (e.g. "opuss") should be
+// rejected rather than silently re-encoded as AAC by GetEncoderArgs.
+func (t *AudioOutputTrack) ValidateAudioTrack() error {
+	if _, ok := audioEncoderNames[t.GetCodec()]; !ok {
+		return fmt.Errorf("audio codec %s is not supported", t.GetCodec())
+	}
+
+	return nil
+}
+
+// ValidateAudioTracks validates every track in the slice, as used by a
+// variant's full set of AudioTracks.
+func ValidateAudioTracks(tracks []AudioOutputTrack) error {
+	for i := range tracks {
+		if err := tracks[i].ValidateAudioTrack(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetHLSGroupID returns the `GROUP-ID` this track's rendition should be
+// placed in. Tracks sharing a codec share a group, since a variant's
+// `#EXT-X-STREAM-INF` can only reference a single audio group at a time.
+func (t *AudioOutputTrack) GetHLSGroupID() string {
+	return fmt.Sprintf("audio-%s", t.GetCodec())
+}
+
+// GetHLSMediaTag returns the `#EXT-X-MEDIA:TYPE=AUDIO` line describing this
+// track for the HLS master playlist, referencing the given media playlist
+// URI.
+func (t *AudioOutputTrack) GetHLSMediaTag(uri string) string {
+	defaultValue := "NO"
+	if t.Default {
+		defaultValue = "YES"
+	}
+
+	tag := fmt.Sprintf(`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="%s",NAME="%s",AUTOSELECT=YES,DEFAULT=%s`, t.GetHLSGroupID(), t.GetName(), defaultValue)
+
+	if t.Language != "" {
+		tag += fmt.Sprintf(`,LANGUAGE="%s"`, t.Language)
+	}
+
+	tag += fmt.Sprintf(`,URI="%s"`, uri)
+
+	return tag
+}
@@ -0,0 +1,39 @@
+package models
+
+import "testing"
+
+func TestValidateAudioTrack(t *testing.T) {
+	tests := []struct {
+		codec   string
+		wantErr bool
+	}{
+		{codec: "aac", wantErr: false},
+		{codec: "opus", wantErr: false},
+		{codec: "mp3", wantErr: false},
+		{codec: "", wantErr: false}, // empty defaults to aac
+		{codec: "opuss", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		track := AudioOutputTrack{Codec: tt.codec}
+		err := track.ValidateAudioTrack()
+		if tt.wantErr && err == nil {
+			t.Errorf("ValidateAudioTrack() with codec %q = nil, want an error", tt.codec)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("ValidateAudioTrack() with codec %q = %v, want nil", tt.codec, err)
+		}
+	}
+}
+
+func TestValidateAudioTracks(t *testing.T) {
+	tracks := []AudioOutputTrack{{Codec: "aac"}, {Codec: "opus"}}
+	if err := ValidateAudioTracks(tracks); err != nil {
+		t.Errorf("ValidateAudioTracks() = %v, want nil for all-valid tracks", err)
+	}
+
+	tracks = []AudioOutputTrack{{Codec: "aac"}, {Codec: "opuss"}}
+	if err := ValidateAudioTracks(tracks); err == nil {
+		t.Errorf("ValidateAudioTracks() = nil, want an error: one track has an unsupported codec")
+	}
+}
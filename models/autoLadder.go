@@ -0,0 +1,77 @@
+package models
+
+// SourceProbe describes the characteristics of the incoming stream as
+// reported by the ingest probe, used to synthesize an output ladder.
+type SourceProbe struct {
+	Width     int
+	Height    int
+	Bitrate   int // in bits per second
+	Framerate int
+	Codec     VideoCodec
+}
+
+// ladderRung describes one rung of the default resolution/bitrate table that
+// auto ladder generation prunes and scales from. The bitrates follow the
+// same rough table other open-source transcoders ship as sane defaults.
+type ladderRung struct {
+	height  int
+	bitrate int // in bits per second
+}
+
+var defaultLadderRungs = []ladderRung{
+	{height: 360, bitrate: 500_000},
+	{height: 480, bitrate: 1_200_000},
+	{height: 720, bitrate: 2_500_000},
+	{height: 1080, bitrate: 3_500_000},
+	{height: 1440, bitrate: 6_000_000},
+	{height: 2160, bitrate: 10_000_000},
+}
+
+// nonH264BitrateMultiplier is applied to the default ladder's bitrate
+// targets when the source isn't already h264, since less efficient input
+// codecs need a larger bitrate budget to transcode to without regressing
+// quality.
+const nonH264BitrateMultiplier = 2.0
+
+// AutoLadder synthesizes a slice of StreamOutputVariant from a probed
+// source, following the default resolution/bitrate table and pruning any
+// rungs above the source's own resolution. Rungs are returned ordered from
+// lowest to highest quality.
+func AutoLadder(source SourceProbe) []StreamOutputVariant {
+	multiplier := 1.0
+	if source.Codec != "" && source.Codec != VideoCodecH264 {
+		multiplier = nonH264BitrateMultiplier
+	}
+
+	variants := []StreamOutputVariant{}
+	for _, rung := range defaultLadderRungs {
+		if source.Height > 0 && rung.height > source.Height {
+			continue
+		}
+
+		variants = append(variants, StreamOutputVariant{
+			VideoBitrate:  int(float64(rung.bitrate) * multiplier / 1000),
+			AudioBitrate:  79,
+			ScaledHeight:  rung.height,
+			Framerate:     source.Framerate,
+			EncoderPreset: "veryfast",
+			CPUUsageLevel: 3,
+		})
+	}
+
+	if len(variants) == 0 {
+		// The source is smaller than our lowest rung; fall back to a
+		// single variant matching the source so there's always something
+		// to serve.
+		variants = append(variants, StreamOutputVariant{
+			VideoBitrate:  int(float64(source.Bitrate) * multiplier / 1000),
+			AudioBitrate:  79,
+			ScaledHeight:  source.Height,
+			Framerate:     source.Framerate,
+			EncoderPreset: "veryfast",
+			CPUUsageLevel: 3,
+		})
+	}
+
+	return variants
+}
@@ -0,0 +1,49 @@
+package models
+
+import "testing"
+
+func TestAutoLadderPrunesRungsAboveSource(t *testing.T) {
+	variants := AutoLadder(SourceProbe{Width: 1280, Height: 720, Bitrate: 2_500_000, Framerate: 30, Codec: VideoCodecH264})
+
+	for _, v := range variants {
+		if v.ScaledHeight > 720 {
+			t.Errorf("AutoLadder() produced a %dp rung above the 720p source", v.ScaledHeight)
+		}
+	}
+
+	if len(variants) == 0 {
+		t.Fatal("AutoLadder() returned no variants for a 720p source")
+	}
+
+	top := variants[len(variants)-1]
+	if top.ScaledHeight != 720 {
+		t.Errorf("AutoLadder() top rung = %dp, want 720p", top.ScaledHeight)
+	}
+}
+
+func TestAutoLadderAppliesNonH264Multiplier(t *testing.T) {
+	h264 := AutoLadder(SourceProbe{Width: 1920, Height: 1080, Bitrate: 3_500_000, Framerate: 30, Codec: VideoCodecH264})
+	hevc := AutoLadder(SourceProbe{Width: 1920, Height: 1080, Bitrate: 3_500_000, Framerate: 30, Codec: VideoCodecHEVC})
+
+	if len(h264) != len(hevc) {
+		t.Fatalf("expected the same rungs for h264 and hevc sources, got %d vs %d", len(h264), len(hevc))
+	}
+
+	for i := range h264 {
+		if hevc[i].VideoBitrate != h264[i].VideoBitrate*2 {
+			t.Errorf("rung %d: hevc bitrate = %d, want 2x h264 bitrate %d", i, hevc[i].VideoBitrate, h264[i].VideoBitrate)
+		}
+	}
+}
+
+func TestAutoLadderFallsBackBelowSmallestRung(t *testing.T) {
+	variants := AutoLadder(SourceProbe{Width: 320, Height: 180, Bitrate: 300_000, Framerate: 30, Codec: VideoCodecH264})
+
+	if len(variants) != 1 {
+		t.Fatalf("expected a single fallback variant for a source smaller than the lowest rung, got %d", len(variants))
+	}
+
+	if variants[0].ScaledHeight != 180 {
+		t.Errorf("fallback variant height = %d, want the source's own 180p", variants[0].ScaledHeight)
+	}
+}
@@ -0,0 +1,90 @@
+package models
+
+import "strings"
+
+// HWAccel represents a hardware accelerator that can be used to encode a
+// stream output variant.
+type HWAccel string
+
+// The hardware accelerators supported for transcoding, in addition to
+// software (libx264/libx265/libsvtav1/libvpx-vp9) encoding.
+const (
+	HWAccelNone         HWAccel = "none"
+	HWAccelNVENC        HWAccel = "nvenc"
+	HWAccelQSV          HWAccel = "qsv"
+	HWAccelVAAPI        HWAccel = "vaapi"
+	HWAccelVideoToolbox HWAccel = "videotoolbox"
+	HWAccelV4L2M2M      HWAccel = "v4l2m2m"
+)
+
+// hwEncoderNames maps each accelerator and codec combination to the ffmpeg
+// encoder name that accelerator exposes for that codec. A missing entry
+// means the accelerator has no encoder for that codec.
+var hwEncoderNames = map[HWAccel]map[VideoCodec]string{
+	HWAccelNVENC: {
+		VideoCodecH264: "h264_nvenc",
+		VideoCodecHEVC: "hevc_nvenc",
+		VideoCodecAV1:  "av1_nvenc",
+	},
+	HWAccelQSV: {
+		VideoCodecH264: "h264_qsv",
+		VideoCodecHEVC: "hevc_qsv",
+		VideoCodecAV1:  "av1_qsv",
+		VideoCodecVP9:  "vp9_qsv",
+	},
+	HWAccelVAAPI: {
+		VideoCodecH264: "h264_vaapi",
+		VideoCodecHEVC: "hevc_vaapi",
+		VideoCodecAV1:  "av1_vaapi",
+		VideoCodecVP9:  "vp9_vaapi",
+	},
+	HWAccelVideoToolbox: {
+		VideoCodecH264: "h264_videotoolbox",
+		VideoCodecHEVC: "hevc_videotoolbox",
+	},
+	HWAccelV4L2M2M: {
+		VideoCodecH264: "h264_v4l2m2m",
+	},
+}
+
+// HWAccelCapabilities describes the hardware encoders available on the
+// host's ffmpeg build.
+type HWAccelCapabilities struct {
+	NVENC        bool
+	QSV          bool
+	VAAPI        bool
+	VideoToolbox bool
+	V4L2M2M      bool
+}
+
+// Supports returns true if the host's ffmpeg build exposes the given
+// accelerator.
+func (c HWAccelCapabilities) Supports(accel HWAccel) bool {
+	switch accel {
+	case HWAccelNVENC:
+		return c.NVENC
+	case HWAccelQSV:
+		return c.QSV
+	case HWAccelVAAPI:
+		return c.VAAPI
+	case HWAccelVideoToolbox:
+		return c.VideoToolbox
+	case HWAccelV4L2M2M:
+		return c.V4L2M2M
+	default:
+		return true // software encoding is always available
+	}
+}
+
+// ParseHWAccelCapabilities parses the output of `ffmpeg -hide_banner
+// -encoders` into the set of hardware accelerators this host's ffmpeg
+// build can use.
+func ParseHWAccelCapabilities(ffmpegEncodersOutput string) HWAccelCapabilities {
+	return HWAccelCapabilities{
+		NVENC:        strings.Contains(ffmpegEncodersOutput, "_nvenc"),
+		QSV:          strings.Contains(ffmpegEncodersOutput, "_qsv"),
+		VAAPI:        strings.Contains(ffmpegEncodersOutput, "_vaapi"),
+		VideoToolbox: strings.Contains(ffmpegEncodersOutput, "_videotoolbox"),
+		V4L2M2M:      strings.Contains(ffmpegEncodersOutput, "_v4l2m2m"),
+	}
+}
@@ -2,6 +2,49 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+)
+
+// VideoCodec represents the video codec used to encode a stream output variant.
+type VideoCodec string
+
+// The video codecs supported for transcoding and passthrough.
+const (
+	VideoCodecH264 VideoCodec = "h264"
+	VideoCodecHEVC VideoCodec = "hevc"
+	VideoCodecAV1  VideoCodec = "av1"
+	VideoCodecVP9  VideoCodec = "vp9"
+)
+
+// SegmentFormat represents the container used for a stream output
+// variant's HLS segments.
+type SegmentFormat string
+
+// The segment containers supported for HLS delivery.
+const (
+	// SegmentFormatTS packages segments as MPEG-TS, the format owncast has
+	// always used.
+	SegmentFormatTS SegmentFormat = "ts"
+	// SegmentFormatFMP4 packages segments as fragmented MP4/CMAF, required
+	// for HEVC/AV1 playback in Safari and for low-latency HLS.
+	SegmentFormatFMP4 SegmentFormat = "fmp4"
+)
+
+// RateControlMode represents the ffmpeg rate control strategy used to
+// encode a stream output variant.
+type RateControlMode string
+
+// The rate control modes supported for transcoded variants.
+const (
+	// RateControlCBR targets a constant bitrate, the most predictable
+	// option for bandwidth-constrained HLS delivery.
+	RateControlCBR RateControlMode = "CBR"
+	// RateControlVBR targets an average bitrate but allows it to vary
+	// within a ceiling, trading predictability for quality.
+	RateControlVBR RateControlMode = "VBR"
+	// RateControlCRF targets a constant quality level with no bitrate
+	// ceiling, best suited for quality-first archival.
+	RateControlCRF RateControlMode = "CRF"
 )
 
 // StreamOutputVariant defines the output specifics of a single HLS stream variant.
@@ -13,6 +56,10 @@ type StreamOutputVariant struct {
 	IsAudioPassthrough bool `yaml:"audioPassthrough" json:"audioPassthrough"`
 
 	VideoBitrate int `yaml:"videoBitrate" json:"videoBitrate"`
+	// AudioBitrate and IsAudioPassthrough are deprecated in favor of
+	// AudioTracks. They're kept so older configs and API clients that only
+	// know about a single audio track keep decoding correctly; see
+	// GetAudioTracks.
 	AudioBitrate int `yaml:"audioBitrate" json:"audioBitrate"`
 
 	// Set only one of these in order to keep your current aspect ratio.
@@ -24,6 +71,40 @@ type StreamOutputVariant struct {
 	EncoderPreset string `yaml:"encoderPreset" json:"encoderPreset"` // Remove after migration is no longer used
 	// CPUUsageLevel represents a codec preset to configure CPU usage.
 	CPUUsageLevel int `json:"cpuUsageLevel"`
+
+	// VideoCodec is the codec used to encode this variant. Defaults to h264
+	// when empty so existing configs keep encoding the way they always have.
+	VideoCodec VideoCodec `yaml:"videoCodec" json:"videoCodec,omitempty"`
+
+	// RateControlMode selects how VideoBitrate and friends are applied.
+	// Defaults to CBR at VideoBitrate when empty so existing configs keep
+	// encoding the way they always have.
+	RateControlMode RateControlMode `yaml:"rateControlMode" json:"rateControlMode,omitempty"`
+	// VBRQuality is the quality-based average bitrate hint used in VBR mode,
+	// expressed as a percentage (0-100) of MaxBitrate.
+	VBRQuality int `yaml:"vbrQuality" json:"vbrQuality,omitempty"`
+	// CRF is the libx264/libx265 constant rate factor used in CRF mode,
+	// where lower values mean higher quality. Typical values are 18-28.
+	CRF int `yaml:"crf" json:"crf,omitempty"`
+	// MaxBitrate is the peak bitrate allowed in VBR mode.
+	MaxBitrate int `yaml:"maxBitrate" json:"maxBitrate,omitempty"`
+	// BufSize is the rate control buffer size used alongside MaxBitrate.
+	BufSize int `yaml:"bufSize" json:"bufSize,omitempty"`
+
+	// HWAccel selects a hardware accelerator to encode this variant with
+	// instead of software encoding. Defaults to none.
+	HWAccel HWAccel `yaml:"hwAccel" json:"hwAccel,omitempty"`
+
+	// AudioTracks are the audio renditions produced alongside this
+	// variant's video. When empty, GetAudioTracks synthesizes a single
+	// default AAC track from AudioBitrate/IsAudioPassthrough.
+	AudioTracks []AudioOutputTrack `yaml:"audioTracks" json:"audioTracks,omitempty"`
+
+	// SegmentFormat selects the HLS segment container. Defaults to ts,
+	// preserving today's behavior.
+	SegmentFormat SegmentFormat `yaml:"segmentFormat" json:"segmentFormat,omitempty"`
+	// SegmentDuration is the target HLS segment duration in seconds.
+	SegmentDuration int `yaml:"segmentDuration" json:"segmentDuration,omitempty"`
 }
 
 // GetFramerate returns the framerate or default.
@@ -52,17 +133,414 @@ func (q *StreamOutputVariant) GetEncoderPreset() string {
 	return "veryfast"
 }
 
-// GetCPUUsageLevel will return the libx264 codec encoder preset that maps to a level.
+// libx264 and libx265 share the same named presets, so libx265 (hevc)
+// reuses the libx264 table below.
+var libx264PresetLevels = map[string]int{
+	"ultrafast": 1,
+	"superfast": 2,
+	"veryfast":  3,
+	"faster":    4,
+	"fast":      5,
+}
+
+// GetCPUUsageLevel will return the level that the libx264/libx265 codec encoder preset maps to.
 func (q *StreamOutputVariant) GetCPUUsageLevel() int {
-	presetMapping := map[string]int{
-		"ultrafast": 1,
-		"superfast": 2,
-		"veryfast":  3,
-		"faster":    4,
-		"fast":      5,
+	return libx264PresetLevels[q.GetEncoderPreset()]
+}
+
+// GetVideoCodec returns the configured video codec, defaulting to h264 so
+// variants created before codec selection existed keep working unchanged.
+func (q *StreamOutputVariant) GetVideoCodec() VideoCodec {
+	if q.VideoCodec == "" {
+		return VideoCodecH264
+	}
+
+	return q.VideoCodec
+}
+
+// GetCodecEncoderArgs returns the ffmpeg arguments needed to select the
+// encoder and preset/quality level for this variant's configured codec.
+// Each codec's preset scheme is different: libx264/libx265 share named
+// presets, svt-av1 takes a numeric 0 (slowest/best) to 13 (fastest) preset,
+// and libvpx-vp9 is tuned with -deadline/-cpu-used instead of a preset.
+func (q *StreamOutputVariant) GetCodecEncoderArgs() []string {
+	if q.IsVideoPassthrough {
+		return []string{"-c:v", "copy"}
+	}
+
+	switch q.GetVideoCodec() {
+	case VideoCodecHEVC:
+		args := []string{"-c:v", "libx265", "-preset", q.GetEncoderPreset()}
+		if q.GetSegmentFormat() == SegmentFormatFMP4 {
+			// hvc1 is an MP4/fMP4 bitstream tag; it's meaningless (and
+			// never reached, since SupportsTSContainer rejects hevc+ts)
+			// outside that container.
+			args = append(args, "-tag:v", "hvc1")
+		}
+
+		return args
+	case VideoCodecAV1:
+		return []string{"-c:v", "libsvtav1", "-preset", fmt.Sprintf("%d", av1PresetForCPUUsageLevel(q.GetCPUUsageLevel()))}
+	case VideoCodecVP9:
+		return []string{"-c:v", "libvpx-vp9", "-deadline", "good", "-cpu-used", fmt.Sprintf("%d", vp9CPUUsedForCPUUsageLevel(q.GetCPUUsageLevel()))}
+	default:
+		return []string{"-c:v", "libx264", "-preset", q.GetEncoderPreset()}
+	}
+}
+
+// av1PresetForCPUUsageLevel maps the shared 1-5 CPU usage level to svt-av1's
+// 0 (slowest/best quality) to 13 (fastest) numeric preset range.
+func av1PresetForCPUUsageLevel(level int) int {
+	mapping := map[int]int{1: 12, 2: 10, 3: 8, 4: 6, 5: 4}
+	if preset, ok := mapping[level]; ok {
+		return preset
+	}
+
+	return 8
+}
+
+// vp9CPUUsedForCPUUsageLevel maps the shared 1-5 CPU usage level to
+// libvpx-vp9's -cpu-used range of 0 (slowest/best quality) to 5 (fastest).
+func vp9CPUUsedForCPUUsageLevel(level int) int {
+	mapping := map[int]int{1: 5, 2: 4, 3: 2, 4: 1, 5: 0}
+	if cpuUsed, ok := mapping[level]; ok {
+		return cpuUsed
+	}
+
+	return 2
+}
+
+// GetHWAccel returns the configured hardware accelerator, defaulting to
+// none so variants created before accelerator selection existed keep
+// encoding in software unchanged.
+func (q *StreamOutputVariant) GetHWAccel() HWAccel {
+	if q.HWAccel == "" {
+		return HWAccelNone
+	}
+
+	return q.HWAccel
+}
+
+// ValidateHWAccel returns an error if this variant requests a hardware
+// accelerator the host's ffmpeg build doesn't expose an encoder for, either
+// because the accelerator itself is unavailable or because it has no
+// encoder for the variant's codec.
+func (q *StreamOutputVariant) ValidateHWAccel(caps HWAccelCapabilities) error {
+	accel := q.GetHWAccel()
+	if accel == HWAccelNone {
+		return nil
+	}
+
+	if !caps.Supports(accel) {
+		return fmt.Errorf("hardware accelerator %s is not available on this host", accel)
+	}
+
+	if _, ok := hwEncoderNames[accel][q.GetVideoCodec()]; !ok {
+		return fmt.Errorf("hardware accelerator %s has no encoder for codec %s", accel, q.GetVideoCodec())
+	}
+
+	return nil
+}
+
+// GetEncoderArgs returns the full set of ffmpeg arguments selecting the
+// encoder, quality knobs, and rate control for this variant, using a
+// hardware accelerator when one is requested and available, and falling
+// back to GetCodecEncoderArgs/GetRateControlArgs for software encoding
+// otherwise. Each accelerator exposes quality knobs differently: NVENC
+// takes a `p1`..`p7` -preset, QSV takes a -preset, and VAAPI is tuned with
+// -quality; rate control itself is handled by GetHWRateControlArgs since
+// none of these accelerators accept the software encoders' -crf or
+// -minrate/-maxrate flags as-is.
+func (q *StreamOutputVariant) GetEncoderArgs(caps HWAccelCapabilities) []string {
+	if q.IsVideoPassthrough {
+		return []string{"-c:v", "copy"}
+	}
+
+	accel := q.GetHWAccel()
+	if accel == HWAccelNone || q.ValidateHWAccel(caps) != nil {
+		return append(q.GetCodecEncoderArgs(), q.GetRateControlArgs()...)
+	}
+
+	encoder := hwEncoderNames[accel][q.GetVideoCodec()]
+
+	switch accel {
+	case HWAccelNVENC:
+		return append([]string{"-c:v", encoder, "-preset", nvencPresetForCPUUsageLevel(q.GetCPUUsageLevel())}, q.GetHWRateControlArgs(accel)...)
+	case HWAccelQSV:
+		return append([]string{"-c:v", encoder, "-preset", q.GetEncoderPreset()}, q.GetHWRateControlArgs(accel)...)
+	case HWAccelVAAPI:
+		return append([]string{"-c:v", encoder, "-quality", fmt.Sprintf("%d", q.GetCPUUsageLevel())}, q.GetHWRateControlArgs(accel)...)
+	case HWAccelVideoToolbox:
+		return append([]string{"-c:v", encoder}, q.GetHWRateControlArgs(accel)...)
+	default:
+		return append([]string{"-c:v", encoder}, q.GetHWRateControlArgs(accel)...)
+	}
+}
+
+// nvencPresetForCPUUsageLevel maps the shared 1-5 CPU usage level to
+// NVENC's p1 (fastest) to p7 (slowest/best quality) preset range.
+func nvencPresetForCPUUsageLevel(level int) string {
+	mapping := map[int]string{1: "p1", 2: "p2", 3: "p4", 4: "p6", 5: "p7"}
+	if preset, ok := mapping[level]; ok {
+		return preset
+	}
+
+	return "p4"
+}
+
+// GetHWRateControlArgs returns the ffmpeg rate control arguments for this
+// variant's configured RateControlMode on the given hardware accelerator.
+// Unlike GetRateControlArgs (which targets the software libx264/libx265
+// family), every accelerator exposes a different set of rate control flags
+// and none of them accept a plain -crf, so each is mapped to its closest
+// equivalent: NVENC uses -rc cbr/vbr with -cq for quality mode, QSV and
+// VAAPI use -global_quality/-qp for quality mode, and VideoToolbox has no
+// bitrate ceiling controls so quality mode falls back to -q:v.
+func (q *StreamOutputVariant) GetHWRateControlArgs(accel HWAccel) []string {
+	mode := q.GetRateControlMode()
+
+	switch accel {
+	case HWAccelNVENC:
+		if mode == RateControlCRF {
+			return []string{"-rc", "vbr", "-cq", fmt.Sprintf("%d", q.GetCRF())}
+		}
+
+		if mode == RateControlVBR {
+			return []string{"-rc", "vbr", "-b:v", fmt.Sprintf("%dk", q.GetVBRTargetBitrate()), "-maxrate", fmt.Sprintf("%dk", q.GetMaxBitrate()), "-bufsize", fmt.Sprintf("%dk", q.GetBufSize())}
+		}
+
+		return []string{"-rc", "cbr", "-b:v", fmt.Sprintf("%dk", q.VideoBitrate), "-maxrate", fmt.Sprintf("%dk", q.VideoBitrate), "-bufsize", fmt.Sprintf("%dk", q.GetBufSize())}
+	case HWAccelQSV:
+		if mode == RateControlCRF {
+			return []string{"-global_quality", fmt.Sprintf("%d", q.GetCRF())}
+		}
+
+		bitrate, maxrate := q.VideoBitrate, q.VideoBitrate
+		if mode == RateControlVBR {
+			bitrate, maxrate = q.GetVBRTargetBitrate(), q.GetMaxBitrate()
+		}
+
+		return []string{"-b:v", fmt.Sprintf("%dk", bitrate), "-maxrate", fmt.Sprintf("%dk", maxrate), "-bufsize", fmt.Sprintf("%dk", q.GetBufSize())}
+	case HWAccelVAAPI:
+		if mode == RateControlCRF {
+			return []string{"-qp", fmt.Sprintf("%d", q.GetCRF())}
+		}
+
+		bitrate, maxrate := q.VideoBitrate, q.VideoBitrate
+		if mode == RateControlVBR {
+			bitrate, maxrate = q.GetVBRTargetBitrate(), q.GetMaxBitrate()
+		}
+
+		return []string{"-b:v", fmt.Sprintf("%dk", bitrate), "-maxrate", fmt.Sprintf("%dk", maxrate), "-bufsize", fmt.Sprintf("%dk", q.GetBufSize())}
+	case HWAccelVideoToolbox:
+		if mode == RateControlCRF {
+			return []string{"-q:v", fmt.Sprintf("%d", q.GetCPUUsageLevel())}
+		}
+
+		return []string{"-b:v", fmt.Sprintf("%dk", q.VideoBitrate)}
+	default:
+		return q.GetRateControlArgs()
 	}
+}
 
-	return presetMapping[q.GetEncoderPreset()]
+// GetHLSCodecString returns the value this variant should contribute to an
+// HLS master playlist's `CODECS=` attribute for its video codec. The profile
+// segment of each string is a reasonable default rather than one probed from
+// the actual encoded stream.
+func (q *StreamOutputVariant) GetHLSCodecString() string {
+	switch q.GetVideoCodec() {
+	case VideoCodecHEVC:
+		return "hvc1.1.6.L93.90"
+	case VideoCodecAV1:
+		return "av01.0.04M.08"
+	case VideoCodecVP9:
+		return "vp09.00.10.08"
+	default:
+		return "avc1.64001f"
+	}
+}
+
+// SupportsFMP4Container returns true if this variant's video codec can be
+// packaged into fMP4/CMAF segments. All of our supported codecs can.
+func (q *StreamOutputVariant) SupportsFMP4Container() bool {
+	return true
+}
+
+// SupportsTSContainer returns true if this variant's video codec can be
+// packaged into legacy MPEG-TS segments. HEVC has spotty TS support across
+// players and AV1/VP9 have none, so passthrough into TS is only considered
+// safe for h264.
+func (q *StreamOutputVariant) SupportsTSContainer() bool {
+	return q.GetVideoCodec() == VideoCodecH264
+}
+
+// GetSegmentFormat returns the configured HLS segment container, defaulting
+// to ts so variants created before segment format selection existed keep
+// segmenting the way they always have. Codecs that ts can't carry (see
+// SupportsTSContainer) default to fmp4 instead, so selecting hevc/av1/vp9
+// doesn't produce an unusable variant without also setting SegmentFormat.
+func (q *StreamOutputVariant) GetSegmentFormat() SegmentFormat {
+	if q.SegmentFormat != "" {
+		return q.SegmentFormat
+	}
+
+	if !q.SupportsTSContainer() {
+		return SegmentFormatFMP4
+	}
+
+	return SegmentFormatTS
+}
+
+// GetSegmentDuration returns the configured HLS segment duration in
+// seconds, or owncast's long-standing default.
+func (q *StreamOutputVariant) GetSegmentDuration() int {
+	if q.SegmentDuration > 0 {
+		return q.SegmentDuration
+	}
+
+	return 4
+}
+
+// ValidateSegmentFormat returns an error if this variant's video codec
+// can't be packaged into its configured segment container.
+func (q *StreamOutputVariant) ValidateSegmentFormat() error {
+	if q.GetSegmentFormat() == SegmentFormatFMP4 && !q.SupportsFMP4Container() {
+		return fmt.Errorf("video codec %s cannot be packaged as fmp4 segments", q.GetVideoCodec())
+	}
+
+	if q.GetSegmentFormat() == SegmentFormatTS && !q.SupportsTSContainer() {
+		return fmt.Errorf("video codec %s cannot be packaged as ts segments", q.GetVideoCodec())
+	}
+
+	return nil
+}
+
+// ValidateSegmentFormats checks that every variant's segment format is
+// individually valid for its codec, and that fmp4 and ts variants aren't
+// mixed in the same ladder: a single master playlist can only declare one
+// #EXT-X-VERSION, and mixed containers would require per-rendition version
+// negotiation most players don't support.
+func ValidateSegmentFormats(variants []StreamOutputVariant) error {
+	seen := map[SegmentFormat]bool{}
+
+	for i := range variants {
+		if err := variants[i].ValidateSegmentFormat(); err != nil {
+			return err
+		}
+
+		seen[variants[i].GetSegmentFormat()] = true
+	}
+
+	if seen[SegmentFormatTS] && seen[SegmentFormatFMP4] {
+		return fmt.Errorf("cannot mix ts and fmp4 segment formats across variants in the same output ladder")
+	}
+
+	return nil
+}
+
+// GetHLSVersion returns the `#EXT-X-VERSION` the master playlist must
+// declare for this output ladder: fmp4 segments require EXT-X-MAP support,
+// introduced in version 7, while ts segments are served at today's
+// version 3 for maximum compatibility.
+func GetHLSVersion(variants []StreamOutputVariant) int {
+	for i := range variants {
+		if variants[i].GetSegmentFormat() == SegmentFormatFMP4 {
+			return 7
+		}
+	}
+
+	return 3
+}
+
+// GetHLSMapTag returns the `#EXT-X-MAP` line media playlists must emit
+// ahead of a fmp4 variant's segments, pointing at its init segment.
+func (q *StreamOutputVariant) GetHLSMapTag(initSegmentURI string) string {
+	return fmt.Sprintf(`#EXT-X-MAP:URI="%s"`, initSegmentURI)
+}
+
+// GetRateControlMode returns the configured rate control mode, defaulting
+// to CBR so variants created before rate control modes existed keep
+// encoding at a constant VideoBitrate unchanged.
+func (q *StreamOutputVariant) GetRateControlMode() RateControlMode {
+	if q.RateControlMode == "" {
+		return RateControlCBR
+	}
+
+	return q.RateControlMode
+}
+
+// GetCRF returns the configured CRF value or a sane default for CRF mode.
+func (q *StreamOutputVariant) GetCRF() int {
+	if q.CRF > 0 {
+		return q.CRF
+	}
+
+	return 23
+}
+
+// GetMaxBitrate returns the configured peak bitrate for VBR mode, defaulting
+// to 1.5x VideoBitrate when unset so a bare VideoBitrate is still a
+// reasonable ceiling.
+func (q *StreamOutputVariant) GetMaxBitrate() int {
+	if q.MaxBitrate > 0 {
+		return q.MaxBitrate
+	}
+
+	return q.VideoBitrate * 3 / 2
+}
+
+// GetBufSize returns the configured rate control buffer size, defaulting to
+// 2x the relevant bitrate ceiling, a common rule of thumb for HLS delivery.
+func (q *StreamOutputVariant) GetBufSize() int {
+	if q.BufSize > 0 {
+		return q.BufSize
+	}
+
+	if q.GetRateControlMode() == RateControlVBR {
+		return q.GetMaxBitrate() * 2
+	}
+
+	return q.VideoBitrate * 2
+}
+
+// GetVBRTargetBitrate returns the average bitrate VBR mode should target.
+// When VBRQuality is set, it's treated as a percentage (0-100) of
+// GetMaxBitrate(); otherwise VideoBitrate is used as the target directly.
+func (q *StreamOutputVariant) GetVBRTargetBitrate() int {
+	if q.VBRQuality > 0 {
+		return q.GetMaxBitrate() * q.VBRQuality / 100
+	}
+
+	return q.VideoBitrate
+}
+
+// GetRateControlArgs returns the ffmpeg arguments controlling bitrate for
+// this variant's rate control mode: CBR pins VideoBitrate via
+// -b:v/-minrate/-maxrate/-bufsize, CRF hands quality control to -crf with
+// no bitrate ceiling, and VBR allows the encoder to vary within
+// -maxrate/-bufsize around a GetVBRTargetBitrate() target.
+func (q *StreamOutputVariant) GetRateControlArgs() []string {
+	if q.IsVideoPassthrough {
+		return []string{}
+	}
+
+	switch q.GetRateControlMode() {
+	case RateControlCRF:
+		return []string{"-crf", fmt.Sprintf("%d", q.GetCRF())}
+	case RateControlVBR:
+		return []string{
+			"-b:v", fmt.Sprintf("%dk", q.GetVBRTargetBitrate()),
+			"-maxrate", fmt.Sprintf("%dk", q.GetMaxBitrate()),
+			"-bufsize", fmt.Sprintf("%dk", q.GetBufSize()),
+		}
+	default:
+		return []string{
+			"-b:v", fmt.Sprintf("%dk", q.VideoBitrate),
+			"-minrate", fmt.Sprintf("%dk", q.VideoBitrate),
+			"-maxrate", fmt.Sprintf("%dk", q.VideoBitrate),
+			"-bufsize", fmt.Sprintf("%dk", q.GetBufSize()),
+		}
+	}
 }
 
 // GetIsAudioPassthrough will return if this variant audio is passthrough.
@@ -78,15 +556,39 @@ func (q *StreamOutputVariant) GetIsAudioPassthrough() bool {
 	return false
 }
 
+// GetAudioTracks returns this variant's audio tracks. If none are
+// configured, a single default AAC track is synthesized from the legacy
+// AudioBitrate/IsAudioPassthrough fields so older configs keep encoding
+// audio the way they always have.
+func (q *StreamOutputVariant) GetAudioTracks() []AudioOutputTrack {
+	if len(q.AudioTracks) > 0 {
+		return q.AudioTracks
+	}
+
+	if q.GetIsAudioPassthrough() {
+		return []AudioOutputTrack{}
+	}
+
+	return []AudioOutputTrack{
+		{
+			Codec:   "aac",
+			Bitrate: q.AudioBitrate,
+			Default: true,
+		},
+	}
+}
+
 // MarshalJSON is a custom JSON marshal function for video stream qualities.
 func (q *StreamOutputVariant) MarshalJSON() ([]byte, error) {
 	type Alias StreamOutputVariant
 	return json.Marshal(&struct {
-		Framerate int `json:"framerate"`
+		Framerate   int                `json:"framerate"`
+		AudioTracks []AudioOutputTrack `json:"audioTracks"`
 		*Alias
 	}{
-		Framerate: q.GetFramerate(),
-		Alias:     (*Alias)(q),
+		Framerate:   q.GetFramerate(),
+		AudioTracks: q.GetAudioTracks(),
+		Alias:       (*Alias)(q),
 	})
 }
 
@@ -147,5 +649,59 @@ func (q *StreamOutputVariant) UnmarshalJSON(data []byte) error {
 		q.AudioBitrate = int(v["audioBitrate"].(float64))
 	}
 
+	if videoCodec, ok := v["videoCodec"].(string); ok {
+		q.VideoCodec = VideoCodec(videoCodec)
+	}
+
+	if hwAccel, ok := v["hwAccel"].(string); ok {
+		q.HWAccel = HWAccel(hwAccel)
+	}
+
+	if rateControlMode, ok := v["rateControlMode"].(string); ok && rateControlMode != "" {
+		q.RateControlMode = RateControlMode(rateControlMode)
+	} else {
+		// Back-compat: configs written before rate control modes existed
+		// keep encoding at a constant VideoBitrate.
+		q.RateControlMode = RateControlCBR
+	}
+
+	if vbrQuality, ok := v["vbrQuality"].(float64); ok {
+		q.VBRQuality = int(vbrQuality)
+	}
+
+	if crf, ok := v["crf"].(float64); ok {
+		q.CRF = int(crf)
+	}
+
+	if maxBitrate, ok := v["maxBitrate"].(float64); ok {
+		q.MaxBitrate = int(maxBitrate)
+	}
+
+	if bufSize, ok := v["bufSize"].(float64); ok {
+		q.BufSize = int(bufSize)
+	}
+
+	if segmentFormat, ok := v["segmentFormat"].(string); ok {
+		q.SegmentFormat = SegmentFormat(segmentFormat)
+	}
+
+	if segmentDuration, ok := v["segmentDuration"].(float64); ok {
+		q.SegmentDuration = int(segmentDuration)
+	}
+
+	if audioTracks, ok := v["audioTracks"]; ok && audioTracks != nil {
+		raw, err := json.Marshal(audioTracks)
+		if err != nil {
+			return err
+		}
+
+		var tracks []AudioOutputTrack
+		if err := json.Unmarshal(raw, &tracks); err != nil {
+			return err
+		}
+
+		q.AudioTracks = tracks
+	}
+
 	return nil
 }
@@ -0,0 +1,187 @@
+package models
+
+import "testing"
+
+func TestAV1PresetForCPUUsageLevel(t *testing.T) {
+	tests := []struct {
+		level int
+		want  int
+	}{
+		{level: 1, want: 12},
+		{level: 2, want: 10},
+		{level: 3, want: 8},
+		{level: 4, want: 6},
+		{level: 5, want: 4},
+		{level: 99, want: 8}, // unknown level falls back to the middle ground
+	}
+
+	for _, tt := range tests {
+		if got := av1PresetForCPUUsageLevel(tt.level); got != tt.want {
+			t.Errorf("av1PresetForCPUUsageLevel(%d) = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+
+	// Level 1 ("use less CPU") should land toward the fast/worst-quality end
+	// of svt-av1's own 0 (slowest/best) to 13 (fastest) range, while level 5
+	// ("use more CPU for better quality") lands toward the slow/best end.
+	if av1PresetForCPUUsageLevel(1) <= av1PresetForCPUUsageLevel(5) {
+		t.Errorf("expected level 1 to map to a faster/worse preset than level 5")
+	}
+}
+
+func TestVP9CPUUsedForCPUUsageLevel(t *testing.T) {
+	tests := []struct {
+		level int
+		want  int
+	}{
+		{level: 1, want: 5},
+		{level: 2, want: 4},
+		{level: 3, want: 2},
+		{level: 4, want: 1},
+		{level: 5, want: 0},
+		{level: 99, want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := vp9CPUUsedForCPUUsageLevel(tt.level); got != tt.want {
+			t.Errorf("vp9CPUUsedForCPUUsageLevel(%d) = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+
+	if vp9CPUUsedForCPUUsageLevel(1) <= vp9CPUUsedForCPUUsageLevel(5) {
+		t.Errorf("expected level 1 to map to a faster/worse -cpu-used than level 5")
+	}
+}
+
+func TestNVENCPresetForCPUUsageLevel(t *testing.T) {
+	if nvencPresetForCPUUsageLevel(1) != "p1" {
+		t.Errorf("expected level 1 to map to the fastest NVENC preset p1")
+	}
+
+	if nvencPresetForCPUUsageLevel(5) != "p7" {
+		t.Errorf("expected level 5 to map to the slowest/best NVENC preset p7")
+	}
+}
+
+func TestGetRateControlArgsCBR(t *testing.T) {
+	q := &StreamOutputVariant{VideoBitrate: 2000}
+	args := q.GetRateControlArgs()
+
+	want := []string{"-b:v", "2000k", "-minrate", "2000k", "-maxrate", "2000k", "-bufsize", "4000k"}
+	if !equalStrings(args, want) {
+		t.Errorf("GetRateControlArgs() = %v, want %v", args, want)
+	}
+}
+
+func TestGetRateControlArgsCRF(t *testing.T) {
+	q := &StreamOutputVariant{VideoBitrate: 2000, RateControlMode: RateControlCRF, CRF: 20}
+	args := q.GetRateControlArgs()
+
+	want := []string{"-crf", "20"}
+	if !equalStrings(args, want) {
+		t.Errorf("GetRateControlArgs() = %v, want %v", args, want)
+	}
+}
+
+func TestGetRateControlArgsVBR(t *testing.T) {
+	q := &StreamOutputVariant{VideoBitrate: 2000, RateControlMode: RateControlVBR, MaxBitrate: 3000}
+	args := q.GetRateControlArgs()
+
+	want := []string{"-b:v", "2000k", "-maxrate", "3000k", "-bufsize", "6000k"}
+	if !equalStrings(args, want) {
+		t.Errorf("GetRateControlArgs() = %v, want %v", args, want)
+	}
+}
+
+func TestGetVBRTargetBitrate(t *testing.T) {
+	q := &StreamOutputVariant{VideoBitrate: 2000, RateControlMode: RateControlVBR, MaxBitrate: 4000, VBRQuality: 75}
+	if got, want := q.GetVBRTargetBitrate(), 3000; got != want {
+		t.Errorf("GetVBRTargetBitrate() = %d, want %d", got, want)
+	}
+
+	// Without VBRQuality set, VideoBitrate itself is the target.
+	q = &StreamOutputVariant{VideoBitrate: 2000, RateControlMode: RateControlVBR}
+	if got, want := q.GetVBRTargetBitrate(), 2000; got != want {
+		t.Errorf("GetVBRTargetBitrate() = %d, want %d", got, want)
+	}
+}
+
+func TestGetRateControlArgsPassthrough(t *testing.T) {
+	q := &StreamOutputVariant{IsVideoPassthrough: true}
+	if args := q.GetRateControlArgs(); len(args) != 0 {
+		t.Errorf("GetRateControlArgs() on a passthrough variant = %v, want empty", args)
+	}
+}
+
+func TestValidateHWAccel(t *testing.T) {
+	caps := HWAccelCapabilities{NVENC: true}
+
+	q := &StreamOutputVariant{HWAccel: HWAccelNVENC, VideoCodec: VideoCodecH264}
+	if err := q.ValidateHWAccel(caps); err != nil {
+		t.Errorf("ValidateHWAccel() = %v, want nil for an available accelerator/codec combination", err)
+	}
+
+	q = &StreamOutputVariant{HWAccel: HWAccelQSV, VideoCodec: VideoCodecH264}
+	if err := q.ValidateHWAccel(caps); err == nil {
+		t.Errorf("ValidateHWAccel() = nil, want an error for an unavailable accelerator")
+	}
+
+	q = &StreamOutputVariant{HWAccel: HWAccelNVENC, VideoCodec: VideoCodecVP9}
+	if err := q.ValidateHWAccel(caps); err == nil {
+		t.Errorf("ValidateHWAccel() = nil, want an error: NVENC has no VP9 encoder")
+	}
+
+	q = &StreamOutputVariant{}
+	if err := q.ValidateHWAccel(caps); err != nil {
+		t.Errorf("ValidateHWAccel() = %v, want nil when no accelerator is requested", err)
+	}
+}
+
+func TestValidateSegmentFormat(t *testing.T) {
+	q := &StreamOutputVariant{VideoCodec: VideoCodecHEVC, SegmentFormat: SegmentFormatTS}
+	if err := q.ValidateSegmentFormat(); err == nil {
+		t.Errorf("ValidateSegmentFormat() = nil, want an error: hevc cannot be packaged as ts")
+	}
+
+	q = &StreamOutputVariant{VideoCodec: VideoCodecHEVC}
+	if err := q.ValidateSegmentFormat(); err != nil {
+		t.Errorf("ValidateSegmentFormat() = %v, want nil: hevc should default to fmp4", err)
+	}
+
+	q = &StreamOutputVariant{VideoCodec: VideoCodecH264}
+	if err := q.ValidateSegmentFormat(); err != nil {
+		t.Errorf("ValidateSegmentFormat() = %v, want nil for the default h264/ts combination", err)
+	}
+}
+
+func TestValidateSegmentFormats(t *testing.T) {
+	variants := []StreamOutputVariant{
+		{VideoCodec: VideoCodecH264, SegmentFormat: SegmentFormatTS},
+		{VideoCodec: VideoCodecHEVC, SegmentFormat: SegmentFormatFMP4},
+	}
+	if err := ValidateSegmentFormats(variants); err == nil {
+		t.Errorf("ValidateSegmentFormats() = nil, want an error: mixing ts and fmp4 isn't allowed")
+	}
+
+	variants = []StreamOutputVariant{
+		{VideoCodec: VideoCodecH264, SegmentFormat: SegmentFormatTS},
+		{VideoCodec: VideoCodecH264, SegmentFormat: SegmentFormatTS},
+	}
+	if err := ValidateSegmentFormats(variants); err != nil {
+		t.Errorf("ValidateSegmentFormats() = %v, want nil for a uniform ts ladder", err)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}